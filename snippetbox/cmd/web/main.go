@@ -1,16 +1,44 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
 	"flag"
-	"log"
+	"html/template"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"snippetbox.yehtet.net/snippetbox/internal/models"
+
+	"github.com/alexedwards/scs/mysqlstore"
+	"github.com/alexedwards/scs/v2"
+	_ "github.com/go-sql-driver/mysql"
 )
 
+// application holds the dependencies that our handlers need, so that we
+// can inject them without resorting to global variables.
+type application struct {
+	logger         *slog.Logger
+	snippets       *models.SnippetModel
+	templateCache  map[string]*template.Template
+	sessionManager *scs.SessionManager
+	secureCookies  bool
+}
+
 func main() {
 	// Define a new command-line flag with the name 'addr', a default value of ":4000"
 	// and some short help text explaining what the flag controls. The value of the
 	// flag will be store in the addr variable at runtime.
 	addr := flag.String("addr", ":4000", "HTTP network address")
+	dsn := flag.String("dsn", "web:pass@/snippetbox?parseTime=true", "MySQL data source name")
+	tlsCert := flag.String("tls-cert", "", "Path to the TLS certificate, enables HTTPS if set together with -tls-key")
+	tlsKey := flag.String("tls-key", "", "Path to the TLS key, enables HTTPS if set together with -tls-cert")
 
 	// Importantly, we use teh flag.Parse() function to parse the command-line flag.
 	// This reads in the command-line flag value and assigns it to the addr
@@ -19,18 +47,99 @@ func main() {
 	// are encountered during parsing the application will be terminated.
 	flag.Parse()
 
-	mux := http.NewServeMux()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	db, err := openDB(*dsn)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	templateCache, err := newTemplateCache()
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	sessionManager := scs.New()
+	sessionManager.Store = mysqlstore.New(db)
+	sessionManager.Lifetime = 12 * time.Hour
+
+	useTLS := *tlsCert != "" && *tlsKey != ""
+
+	app := &application{
+		logger:         logger,
+		snippets:       &models.SnippetModel{DB: db},
+		templateCache:  templateCache,
+		sessionManager: sessionManager,
+		secureCookies:  useTLS,
+	}
+
+	tlsConfig := &tls.Config{
+		CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256},
+	}
+
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      app.routes(),
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
+		TLSConfig:    tlsConfig,
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
 
-	fileServer := http.FileServer(http.Dir("./ui/static/"))
-	mux.Handle("/static/", http.StripPrefix("/static", fileServer))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	mux.HandleFunc("/", home)
-	mux.HandleFunc("/snippet/view", snippetView)
-	mux.HandleFunc("/snippet/create", snippetCreate)
+	shutdownError := make(chan error)
 
-	log.Printf("Starting http server on %s", *addr)
-	err := http.ListenAndServe(*addr, mux)
+	go func() {
+		<-ctx.Done()
+
+		logger.Info("shutting down server", slog.String("addr", *addr))
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		shutdownError <- srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("starting server", slog.String("addr", *addr))
+
+	if useTLS {
+		err = srv.ListenAndServeTLS(*tlsCert, *tlsKey)
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	if !errors.Is(err, http.ErrServerClosed) {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	if err = <-shutdownError; err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	db.Close()
+
+	logger.Info("stopped server", slog.String("addr", *addr))
+}
+
+// openDB wraps sql.Open() and verifies that a connection can actually be
+// established, returning a ready-to-use connection pool.
+func openDB(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		log.Fatalf("Failed to start the server: %s", err)
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
 	}
+
+	return db, nil
 }
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/justinas/nosurf"
+)
+
+// noSurf uses a customized CSRF cookie with the Path and HttpOnly
+// attributes set. Secure is only set when the server is actually running
+// behind TLS (app.secureCookies) — a Secure cookie set over plain HTTP is
+// silently dropped by the browser, which would make every form submission
+// fail CSRF validation.
+func (app *application) noSurf(next http.Handler) http.Handler {
+	csrfHandler := nosurf.New(next)
+	csrfHandler.SetBaseCookie(http.Cookie{
+		HttpOnly: true,
+		Path:     "/",
+		Secure:   app.secureCookies,
+	})
+
+	return csrfHandler
+}
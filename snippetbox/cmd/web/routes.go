@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+
+	"snippetbox.yehtet.net/snippetbox/ui"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// routes returns an http.Handler: a httprouter.Router containing our
+// application routes, wrapped in the session and CSRF middleware.
+func (app *application) routes() http.Handler {
+	router := httprouter.New()
+
+	router.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		app.notFound(w)
+	})
+
+	// Serve the embedded static assets directly; the embedded paths
+	// already begin with "static/", so no prefix stripping is needed.
+	router.Handler(http.MethodGet, "/static/*filepath", http.FileServer(http.FS(ui.Files)))
+
+	router.HandlerFunc(http.MethodGet, "/", app.home)
+	router.HandlerFunc(http.MethodGet, "/snippet/view/:id", app.snippetView)
+	router.HandlerFunc(http.MethodGet, "/snippet/create", app.snippetCreate)
+	router.HandlerFunc(http.MethodPost, "/snippet/create", app.snippetCreatePost)
+
+	// Wrap the router with the session and CSRF middleware so that every
+	// request gets a session loaded/saved around it and every form is
+	// protected against CSRF.
+	return app.sessionManager.LoadAndSave(app.noSurf(router))
+}
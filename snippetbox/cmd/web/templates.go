@@ -2,24 +2,42 @@ package main
 
 import (
 	"html/template"
+	"io/fs"
+	"net/http"
 	"path/filepath"
 
 	"snippetbox.yehtet.net/snippetbox/internal/models"
+	"snippetbox.yehtet.net/snippetbox/ui"
+
+	"github.com/justinas/nosurf"
 )
 
 // Include a Snippets field.
 type templateData struct {
-	Snippet  *models.Snippet
-	Snippets []*models.Snippet
+	Snippet   *models.Snippet
+	Snippets  []*models.Snippet
+	Form      any
+	Flash     string
+	CSRFToken string
+}
+
+// newTemplateData returns a templateData struct initialized with the
+// fields that are common to every page, such as the flash message (if
+// any) and the CSRF token for the current request.
+func (app *application) newTemplateData(r *http.Request) templateData {
+	return templateData{
+		Flash:     app.sessionManager.PopString(r.Context(), "flash"),
+		CSRFToken: nosurf.Token(r),
+	}
 }
 
 func newTemplateCache() (map[string]*template.Template, error) {
 	// Initialize a new map to act as the cache.
 	cache := map[string]*template.Template{}
 
-	// Use the filepath.Glob() function to get a slice of all filepaths that
-	// match the pattern.
-	pages, err := filepath.Glob("./ui/html/pages/*.tmpl.html")
+	// Use fs.Glob() to get a slice of all the filepaths in the embedded
+	// ui.Files filesystem that match the pattern.
+	pages, err := fs.Glob(ui.Files, "html/pages/*.tmpl.html")
 	if err != nil {
 		return nil, err
 	}
@@ -32,14 +50,15 @@ func newTemplateCache() (map[string]*template.Template, error) {
 
 		// Create a slice containing filepaths for our base template, any partial
 		// and the page.
-		files := []string{
-			"./ui/html/base.tmpl.html",
-			"./ui/html/partials/nav.tmpl.html",
+		patterns := []string{
+			"html/base.tmpl.html",
+			"html/partials/*.tmpl.html",
 			page,
 		}
 
-		// Parse the files into a template set.
-		ts, err := template.ParseFiles(files...)
+		// Parse the files into a template set, reading them from the
+		// embedded filesystem instead of disk.
+		ts, err := template.ParseFS(ui.Files, patterns...)
 		if err != nil {
 			return nil, err
 		}
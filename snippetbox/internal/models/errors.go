@@ -0,0 +1,7 @@
+package models
+
+import "errors"
+
+// ErrNoRecord is returned by model methods when a database query finds no
+// matching record.
+var ErrNoRecord = errors.New("models: no matching record found")
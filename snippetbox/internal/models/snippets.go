@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"time"
@@ -22,20 +23,17 @@ type SnippetModel struct {
 }
 
 // Insert() a new snippet into database and return snippet id and error.
-func (m *SnippetModel) Insert(title string, content string, expires int) (int, error) {
+func (m *SnippetModel) Insert(ctx context.Context, title string, content string, expires int) (int, error) {
 
 	// Write the SQL statement we want to execute. I've split it over two lines
 	// for readability (which is why it's surrounded with backquotes instead of
 	// normal double quotes).
-	stmt := `INSERT INTO snippets (title, content, created, expires) 
+	stmt := `INSERT INTO snippets (title, content, created, expires)
 			VALUES(?, ?, UTC_TIMESTAMP(), DATE_ADD(UTC_TIMESTAMP(), INTERVAL ? DAY))`
 
-	// Use the Exec() method on the embedded connection pool to execute the
-	// statement. The first parameter is the SQL statement, followed by the
-	// title, content and expiry values for the placeholder parameters. This
-	// method return sql.Result type, which contains some basic information
-	// about what happened when the statement was executed.
-	result, err := m.DB.Exec(stmt, title, content, expires)
+	// Use ExecContext() so the statement is cancelled if ctx is done before
+	// it completes, e.g. because the server is shutting down.
+	result, err := m.DB.ExecContext(ctx, stmt, title, content, expires)
 	if err != nil {
 		return 0, err
 	}
@@ -51,17 +49,17 @@ func (m *SnippetModel) Insert(title string, content string, expires int) (int, e
 }
 
 // Get() returns a specific snippet based on its id.
-func (m *SnippetModel) Get(id int) (*Snippet, error) {
+func (m *SnippetModel) Get(ctx context.Context, id int) (*Snippet, error) {
 	// Write the SQL statement we want to execute.
 	stmt := `SELECT id, title, content, created, expires FROM snippets
 			WHERE expires > UTC_TIMESTAMP() AND id = ?`
 
-	// Use the QueryRow() method on the connection pool to execute our SQL
-	// statement, passing in the untrusted id variable as the value for the
-	// placeholder parameter. This returns a pointer to sql.Row object which
-	// holds the results from the database.
+	// Use QueryRowContext() to execute our SQL statement, passing in the
+	// untrusted id variable as the value for the placeholder parameter.
+	// This returns a pointer to sql.Row object which holds the results
+	// from the database.
 
-	row := m.DB.QueryRow(stmt, id)
+	row := m.DB.QueryRowContext(ctx, stmt, id)
 
 	// Initialize a pointer to a new zeroed Snipped struct.
 	s := &Snippet{}
@@ -84,6 +82,40 @@ func (m *SnippetModel) Get(id int) (*Snippet, error) {
 }
 
 // Latest() returns the 10 most recently created snippets.
-func (m *SnippetModel) Latest() ([]*Snippet, error) {
-	return nil, nil
+func (m *SnippetModel) Latest(ctx context.Context) ([]*Snippet, error) {
+	stmt := `SELECT id, title, content, created, expires FROM snippets
+			WHERE expires > UTC_TIMESTAMP() ORDER BY id DESC LIMIT 10`
+
+	rows, err := m.DB.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Defer a call to rows.Close() to ensure the resultset is properly
+	// closed before Latest() returns, even if one of the iterations below
+	// returns an error.
+	defer rows.Close()
+
+	snippets := []*Snippet{}
+
+	// Use rows.Next to iterate through the rows in the resultset.
+	for rows.Next() {
+		s := &Snippet{}
+
+		err = rows.Scan(&s.ID, &s.Title, &s.Content, &s.Created, &s.Expires)
+		if err != nil {
+			return nil, err
+		}
+
+		snippets = append(snippets, s)
+	}
+
+	// Call rows.Err() to retrieve any error that was encountered during the
+	// iteration. It's important to call this, don't assume that a
+	// successful iteration was completed over the whole resultset.
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snippets, nil
 }
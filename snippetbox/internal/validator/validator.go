@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator holds the validation errors accumulated while checking a form.
+// FieldErrors are errors tied to a specific input; NonFieldErrors are
+// errors that don't relate to any one field.
+type Validator struct {
+	FieldErrors    map[string]string
+	NonFieldErrors []string
+}
+
+// Valid returns true if there are no field or non-field errors.
+func (v *Validator) Valid() bool {
+	return len(v.FieldErrors) == 0 && len(v.NonFieldErrors) == 0
+}
+
+// AddNonFieldError adds an error message to the NonFieldErrors slice.
+func (v *Validator) AddNonFieldError(message string) {
+	v.NonFieldErrors = append(v.NonFieldErrors, message)
+}
+
+// AddFieldError adds an error message for a given field to the
+// FieldErrors map, as long as an entry doesn't already exist for it.
+func (v *Validator) AddFieldError(key, message string) {
+	if v.FieldErrors == nil {
+		v.FieldErrors = make(map[string]string)
+	}
+
+	if _, exists := v.FieldErrors[key]; !exists {
+		v.FieldErrors[key] = message
+	}
+}
+
+// CheckField adds an error message to the FieldErrors map only if a
+// validation check is not 'ok'.
+func (v *Validator) CheckField(ok bool, key, message string) {
+	if !ok {
+		v.AddFieldError(key, message)
+	}
+}
+
+// NotBlank returns true if a value is not an empty string once leading
+// and trailing whitespace is stripped.
+func NotBlank(value string) bool {
+	return strings.TrimSpace(value) != ""
+}
+
+// MaxChars returns true if a value contains no more than n characters.
+func MaxChars(value string, n int) bool {
+	return utf8.RuneCountInString(value) <= n
+}
+
+// PermittedInt returns true if value is in the list of permittedValues.
+func PermittedInt(value int, permittedValues ...int) bool {
+	for _, v := range permittedValues {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package validator
+
+import "testing"
+
+func TestNotBlank(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"valid string", "hello", true},
+		{"padded string", "  hello  ", true},
+		{"empty string", "", false},
+		{"whitespace only", "   ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NotBlank(tt.value)
+			if got != tt.want {
+				t.Errorf("NotBlank(%q) = %v; want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxChars(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		n     int
+		want  bool
+	}{
+		{"fewer runes than n", "hello", 10, true},
+		{"exactly n runes", "hello", 5, true},
+		{"more runes than n", "hello", 4, false},
+		{"empty string", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaxChars(tt.value, tt.n)
+			if got != tt.want {
+				t.Errorf("MaxChars(%q, %d) = %v; want %v", tt.value, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermittedInt(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           int
+		permittedValues []int
+		want            bool
+	}{
+		{"value permitted", 2, []int{1, 2, 3}, true},
+		{"value not permitted", 4, []int{1, 2, 3}, false},
+		{"no permitted values given", 1, []int{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PermittedInt(tt.value, tt.permittedValues...)
+			if got != tt.want {
+				t.Errorf("PermittedInt(%d, %v) = %v; want %v", tt.value, tt.permittedValues, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckField(t *testing.T) {
+	v := &Validator{}
+
+	v.CheckField(true, "title", "this is ignored")
+	if !v.Valid() {
+		t.Errorf("CheckField(true, ...) added a field error; want no error")
+	}
+
+	v.CheckField(false, "title", "this field cannot be blank")
+	if v.Valid() {
+		t.Errorf("CheckField(false, ...) did not add a field error")
+	}
+	if v.FieldErrors["title"] != "this field cannot be blank" {
+		t.Errorf("FieldErrors[%q] = %q; want %q", "title", v.FieldErrors["title"], "this field cannot be blank")
+	}
+}
@@ -0,0 +1,10 @@
+package ui
+
+import "embed"
+
+// Files embeds the contents of the ui/static and ui/html directories into
+// the compiled binary, so the application has no runtime dependency on
+// the working directory it's run from.
+//
+//go:embed "html" "static"
+var Files embed.FS